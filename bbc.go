@@ -0,0 +1,204 @@
+package forecastio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const bbcBaseURL = "https://weather-broker-cdn.api.bbci.co.uk/en/forecast/aggregated"
+
+// BBCProvider implements Provider against the BBC's aggregated forecast
+// CDN. The BBC does not publish this endpoint as a stable public API, so
+// only the fields this package needs are decoded; locationID is the
+// numeric BBC location identifier found in a bbc.com/weather URL, eg; the
+// "2643743" in bbc.com/weather/2643743 for London.
+type BBCProvider struct {
+	httpClient *http.Client
+	baseURL    string
+	locationID string
+}
+
+// NewBBCProvider returns a Provider backed by the BBC's forecast CDN for
+// the given locationID.
+func NewBBCProvider(locationID string) *BBCProvider {
+	return &BBCProvider{httpClient: http.DefaultClient, baseURL: bbcBaseURL, locationID: locationID}
+}
+
+type bbcReport struct {
+	LocalDate                         string `json:"localDate"`
+	Timeslot                          string `json:"timeslot"`
+	WeatherType                       int    `json:"weatherType"`
+	WeatherTypeText                   string `json:"weatherTypeText"`
+	TemperatureC                      int    `json:"temperatureC"`
+	FeelsLikeTemperatureC             int    `json:"feelsLikeTemperatureC"`
+	WindSpeedMph                      int    `json:"windSpeedMph"`
+	WindDirection                     string `json:"windDirection"`
+	WindDirectionFull                 string `json:"windDirectionFull"`
+	VisibilityText                    string `json:"visibilityText"`
+	HumidityPercent                   int    `json:"humidityPercent"`
+	PressurePascal                    int    `json:"pressurePascal"`
+	PrecipitationProbabilityInPercent string `json:"precipitationProbabilityInPercent"`
+}
+
+type bbcResponse struct {
+	Forecasts []struct {
+		Summary struct {
+			Report bbcReport `json:"report"`
+		} `json:"summary"`
+		Detailed struct {
+			ReportList []bbcReport `json:"reportList"`
+		} `json:"detailed"`
+	} `json:"forecasts"`
+}
+
+func (p *BBCProvider) fetch(ctx context.Context) (*bbcResponse, error) {
+	url := fmt.Sprintf("%s/%s", p.baseURL, p.locationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("forecastio: bbc request failed with status %s", resp.Status)
+	}
+	var parsed bbcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func bbcReportToHourData(r bbcReport) *hourData {
+	icon, summary := iconForBBCType(r.WeatherType, r.WeatherTypeText)
+	t, _ := time.Parse("2006-01-02T15:04", r.LocalDate+"T"+r.Timeslot)
+	probability, _ := strconv.ParseFloat(r.PrecipitationProbabilityInPercent, 64)
+	return &hourData{
+		Time:                     t,
+		TimeUnix:                 t.Unix(),
+		Summary:                  summary,
+		Icon:                     icon,
+		Temperature:              float64(r.TemperatureC),
+		ApparentTemperature:      float64(r.FeelsLikeTemperatureC),
+		Humidity:                 float64(r.HumidityPercent) / 100,
+		PrecipitationProbability: probability / 100,
+		WindSpeed:                float64(r.WindSpeedMph),
+		WindBearing:              compassToBearing(r.WindDirection),
+		Pressure:                 float64(r.PressurePascal) / 100,
+	}
+}
+
+// toForecast builds a Forecast from the aggregated BBC response. The BBC
+// CDN makes a single network call regardless of what's requested, so
+// excludes are honored here by simply not populating the excluded
+// sections rather than by changing what's fetched.
+func (p *BBCProvider) toForecast(resp *bbcResponse, excluded map[string]bool) *Forecast {
+	f := &Forecast{}
+	if len(resp.Forecasts) == 0 {
+		return f
+	}
+
+	if !excluded["currently"] {
+		current := bbcReportToHourData(resp.Forecasts[0].Summary.Report)
+		f.Currently = currently{
+			TimeUnix:                 current.TimeUnix,
+			Time:                     current.Time,
+			Summary:                  current.Summary,
+			Icon:                     current.Icon,
+			Temperature:              current.Temperature,
+			ApparentTemperature:      current.ApparentTemperature,
+			Humidity:                 current.Humidity,
+			PrecipitationProbability: current.PrecipitationProbability,
+			WindSpeed:                current.WindSpeed,
+			WindBearing:              current.WindBearing,
+			Pressure:                 current.Pressure,
+		}
+	}
+
+	for _, day := range resp.Forecasts {
+		if !excluded["hourly"] {
+			for _, r := range day.Detailed.ReportList {
+				f.Hourly.Data = append(f.Hourly.Data, bbcReportToHourData(r))
+			}
+		}
+		if !excluded["daily"] {
+			icon, summary := iconForBBCType(day.Summary.Report.WeatherType, day.Summary.Report.WeatherTypeText)
+			d, _ := time.Parse("2006-01-02", day.Summary.Report.LocalDate)
+			f.Daily.Data = append(f.Daily.Data, &dayData{
+				Time:           d,
+				TimeUnix:       d.Unix(),
+				Summary:        summary,
+				Icon:           icon,
+				TemperatureMax: float64(day.Summary.Report.TemperatureC),
+			})
+		}
+	}
+	if len(f.Hourly.Data) > 0 {
+		f.Hourly.Icon, f.Hourly.Summary = f.Hourly.Data[0].Icon, f.Hourly.Data[0].Summary
+	}
+	if len(f.Daily.Data) > 0 {
+		f.Daily.Icon, f.Daily.Summary = f.Daily.Data[0].Icon, f.Daily.Data[0].Summary
+	}
+	return f
+}
+
+// Forecast implements Provider.
+func (p *BBCProvider) Forecast(ctx context.Context, lat, lon float64, opts ForecastOptions) (*Forecast, error) {
+	resp, err := p.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f := p.toForecast(resp, excludeSet(opts.Excludes))
+	f.Latitude, f.Longitude = lat, lon
+	return f, nil
+}
+
+// ForecastAtTime implements Provider by requesting the aggregated forecast
+// and picking out the hourly entry closest to t; the BBC CDN has no
+// dedicated point-in-time endpoint.
+func (p *BBCProvider) ForecastAtTime(ctx context.Context, lat, lon float64, t time.Time, opts ForecastOptions) (*Forecast, error) {
+	f, err := p.Forecast(ctx, lat, lon, opts)
+	if err != nil {
+		return nil, err
+	}
+	if closest := closestHourData(f.Hourly.Data, t); closest != nil {
+		f.Currently = currentlyFromHourData(closest)
+	}
+	return f, nil
+}
+
+// iconForBBCType maps the BBC's numeric weatherType onto this package's
+// icon vocabulary. The BBC type codes are undocumented; text is used as
+// the summary directly since it is already human-readable.
+func iconForBBCType(weatherType int, text string) (icon, summary string) {
+	switch weatherType {
+	case 0, 1:
+		icon = "clear-night"
+	case 2, 3:
+		icon = "partly-cloudy-night"
+	case 4, 30:
+		icon = "clear-day"
+	case 5, 6, 7, 8:
+		icon = "partly-cloudy-day"
+	case 9, 10, 11, 12, 13, 14:
+		icon = "rain"
+	case 15, 16, 17:
+		icon = "rain"
+	case 18, 19, 20:
+		icon = "fog"
+	case 21, 22, 23, 24, 25, 26, 27:
+		icon = "snow"
+	case 28, 29:
+		icon = "thunderstorm"
+	default:
+		icon = "cloudy"
+	}
+	return icon, text
+}
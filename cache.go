@@ -0,0 +1,149 @@
+package forecastio
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache is implemented by anything that can store and retrieve raw
+// forecast.io response bodies keyed by request, so APIConn can avoid
+// re-fetching data that hasn't gone stale yet. Get reports whether key was
+// found and has not expired; each implementation is responsible for
+// enforcing its own TTL.
+type Cache interface {
+	Get(key string) (body []byte, fetchedAt time.Time, ok bool)
+	Set(key string, body []byte, fetchedAt time.Time)
+}
+
+type memoryCacheEntry struct {
+	key       string
+	body      []byte
+	fetchedAt time.Time
+}
+
+// MemoryCache is an in-process, least-recently-used Cache with a fixed
+// capacity and TTL.
+type MemoryCache struct {
+	mu    sync.Mutex
+	max   int
+	ttl   time.Duration
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// NewMemoryCache returns a MemoryCache holding at most max entries, each
+// valid for ttl after it was stored. A max of 0 means unbounded.
+func NewMemoryCache(max int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		max:   max,
+		ttl:   ttl,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, time.Time{}, false
+	}
+	entry := el.Value.(*memoryCacheEntry)
+	if time.Since(entry.fetchedAt) > c.ttl {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, time.Time{}, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.body, entry.fetchedAt, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, body []byte, fetchedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*memoryCacheEntry)
+		entry.body, entry.fetchedAt = body, fetchedAt
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&memoryCacheEntry{key: key, body: body, fetchedAt: fetchedAt})
+	c.items[key] = el
+
+	if c.max > 0 && c.ll.Len() > c.max {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheEntry).key)
+		}
+	}
+}
+
+// fileCacheEntry is the JSON shape each FileCache entry is stored as on
+// disk.
+type fileCacheEntry struct {
+	Body      []byte    `json:"body"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// FileCache is an on-disk Cache, writing each response as a JSON file whose
+// name is the SHA-256 hash of its cache key. It survives process restarts,
+// at the cost of a filesystem round trip per lookup.
+type FileCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewFileCache returns a FileCache storing entries under dir, each valid
+// for ttl after it was stored. dir is created on first Set if it doesn't
+// already exist.
+func NewFileCache(dir string, ttl time.Duration) *FileCache {
+	return &FileCache{dir: dir, ttl: ttl}
+}
+
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get implements Cache.
+func (c *FileCache) Get(key string) ([]byte, time.Time, bool) {
+	data, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, time.Time{}, false
+	}
+	if time.Since(entry.FetchedAt) > c.ttl {
+		return nil, time.Time{}, false
+	}
+	return entry.Body, entry.FetchedAt, true
+}
+
+// Set implements Cache. Write failures are swallowed, degrading to an
+// uncached fetch next time rather than failing the caller's request.
+func (c *FileCache) Set(key string, body []byte, fetchedAt time.Time) {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+	data, err := json.Marshal(fileCacheEntry{Body: body, FetchedAt: fetchedAt})
+	if err != nil {
+		return
+	}
+	_ = ioutil.WriteFile(c.path(key), data, 0o644)
+}
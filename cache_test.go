@@ -0,0 +1,139 @@
+package forecastio
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheGetSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		max       int
+		ttl       time.Duration
+		setup     func(c *MemoryCache)
+		key       string
+		wantBody  string
+		wantFound bool
+	}{
+		{
+			name: "hit",
+			max:  10,
+			ttl:  time.Hour,
+			setup: func(c *MemoryCache) {
+				c.Set("a", []byte("a-body"), time.Now())
+			},
+			key:       "a",
+			wantBody:  "a-body",
+			wantFound: true,
+		},
+		{
+			name:      "miss",
+			max:       10,
+			ttl:       time.Hour,
+			setup:     func(c *MemoryCache) {},
+			key:       "missing",
+			wantFound: false,
+		},
+		{
+			name: "expired entry is evicted",
+			max:  10,
+			ttl:  time.Millisecond,
+			setup: func(c *MemoryCache) {
+				c.Set("a", []byte("a-body"), time.Now().Add(-time.Hour))
+			},
+			key:       "a",
+			wantFound: false,
+		},
+		{
+			name: "over-capacity evicts the least recently used entry",
+			max:  2,
+			ttl:  time.Hour,
+			setup: func(c *MemoryCache) {
+				c.Set("a", []byte("a-body"), time.Now())
+				c.Set("b", []byte("b-body"), time.Now())
+				c.Get("a") // touch a, so b becomes least-recently-used
+				c.Set("c", []byte("c-body"), time.Now())
+			},
+			key:       "b",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemoryCache(tt.max, tt.ttl)
+			tt.setup(c)
+
+			body, _, ok := c.Get(tt.key)
+			if ok != tt.wantFound {
+				t.Fatalf("Get(%q) found = %v, want %v", tt.key, ok, tt.wantFound)
+			}
+			if ok && string(body) != tt.wantBody {
+				t.Fatalf("Get(%q) body = %q, want %q", tt.key, body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheSetOverwritesExistingEntry(t *testing.T) {
+	c := NewMemoryCache(1, time.Hour)
+	c.Set("a", []byte("first"), time.Now())
+	c.Set("a", []byte("second"), time.Now())
+
+	body, _, ok := c.Get("a")
+	if !ok {
+		t.Fatal("Get(\"a\") = not found, want found")
+	}
+	if string(body) != "second" {
+		t.Fatalf("Get(\"a\") body = %q, want %q", body, "second")
+	}
+}
+
+func TestFileCacheRoundTrip(t *testing.T) {
+	c := NewFileCache(t.TempDir(), time.Hour)
+	fetchedAt := time.Now()
+
+	c.Set("key", []byte("body"), fetchedAt)
+
+	body, got, ok := c.Get("key")
+	if !ok {
+		t.Fatal("Get(\"key\") = not found, want found")
+	}
+	if string(body) != "body" {
+		t.Fatalf("Get(\"key\") body = %q, want %q", body, "body")
+	}
+	if !got.Equal(fetchedAt) {
+		t.Fatalf("Get(\"key\") fetchedAt = %v, want %v", got, fetchedAt)
+	}
+}
+
+func TestFileCacheMissingAndExpired(t *testing.T) {
+	dir := t.TempDir()
+	c := NewFileCache(dir, time.Millisecond)
+
+	if _, _, ok := c.Get("missing"); ok {
+		t.Fatal("Get(\"missing\") = found, want not found")
+	}
+
+	c.Set("stale", []byte("body"), time.Now().Add(-time.Hour))
+	if _, _, ok := c.Get("stale"); ok {
+		t.Fatal("Get(\"stale\") = found, want not found (should be expired)")
+	}
+}
+
+func TestFileCacheKeysAreHashedNotCredentialBearing(t *testing.T) {
+	dir := t.TempDir()
+	c := NewFileCache(dir, time.Hour)
+
+	key := "https://api.forecast.io/forecast/super-secret-key/37.8,-122.4"
+	c.Set(key, []byte("body"), time.Now())
+
+	path := c.path(key)
+	if filepath.Dir(path) != dir {
+		t.Fatalf("path(%q) = %q, want a file directly under %q", key, path, dir)
+	}
+	if filepath.Base(path) == key {
+		t.Fatalf("path(%q) stored the raw key as the filename", key)
+	}
+}
@@ -1,9 +1,9 @@
 package forecastio
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
@@ -41,19 +41,94 @@ var (
 // it's own API key and units settings, it also contains a counter for the
 // number of API calls that day, this value is not populated until a Forecast()
 // or ForecastAtTime() call is made with this APIConn.
+//
+// The RWMutex guards only apiKey, apiCalls, units and cachedAt: the fields
+// that can be read or written concurrently via APICalls/Units/SetUnits/
+// CachedAt. It is not held
+// across the network round trip in Forecast/ForecastAtTime, so multiple
+// forecasts on the same APIConn run in parallel rather than serializing on
+// the connection.
 type APIConn struct {
 	sync.RWMutex
 	apiKey   string
 	apiCalls int
 	units    string
+
+	httpClient     *http.Client
+	pendingTimeout *time.Duration
+	baseURL        string
+	userAgent      string
+	cache          Cache
+	cachedAt       time.Time
+	geocoder       Geocoder
+}
+
+// Option configures an APIConn constructed by NewConnection.
+type Option func(*APIConn)
+
+// WithHTTPClient sets the http.Client used for requests, in place of the
+// zero-value http.Client NewConnection creates by default. Use this to configure transport-level timeouts,
+// proxies or TLS settings.
+func WithHTTPClient(client *http.Client) Option {
+	return func(a *APIConn) { a.httpClient = client }
+}
+
+// WithBaseURL overrides the forecast.io-compatible base URL requests are
+// sent to, for use against a proxy or a mock server in tests.
+func WithBaseURL(url string) Option {
+	return func(a *APIConn) { a.baseURL = url }
+}
+
+// WithTimeout sets a timeout applied to the APIConn's http.Client,
+// regardless of the order WithTimeout and WithHTTPClient are passed to
+// NewConnection in. It never mutates a client passed via WithHTTPClient;
+// NewConnection applies the timeout to a shallow copy after all options
+// have run, so the caller's original *http.Client is left untouched.
+func WithTimeout(d time.Duration) Option {
+	return func(a *APIConn) { a.pendingTimeout = &d }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(a *APIConn) { a.userAgent = userAgent }
+}
+
+// WithCache enables a response cache, checked before every request and
+// populated on every miss. See MemoryCache and FileCache for the shipped
+// implementations.
+func WithCache(cache Cache) Option {
+	return func(a *APIConn) { a.cache = cache }
+}
+
+// WithGeocoder overrides the Geocoder used by ForecastByPlace, in place of
+// the default OpenMeteoGeocoder.
+func WithGeocoder(geocoder Geocoder) Option {
+	return func(a *APIConn) { a.geocoder = geocoder }
 }
 
 // NewConnection returns a new *APIConn setting the APIkey and the units
 // property to "auto", this means that by default the units of values returned
 // will be in the standard units for that country, eg; imperial in the US, a
-// mix in the UK, metric in France.
-func NewConnection(key string) *APIConn {
-	return &APIConn{apiKey: key, units: "auto"}
+// mix in the UK, metric in France. Further options, such as WithHTTPClient
+// or WithTimeout, can be passed to customise the connection.
+func NewConnection(key string, opts ...Option) *APIConn {
+	a := &APIConn{
+		apiKey:     key,
+		units:      "auto",
+		httpClient: &http.Client{},
+		baseURL:    baseURL,
+		geocoder:   NewOpenMeteoGeocoder(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	if a.pendingTimeout != nil {
+		client := *a.httpClient
+		client.Timeout = *a.pendingTimeout
+		a.httpClient = &client
+		a.pendingTimeout = nil
+	}
+	return a
 }
 
 // APICalls returns an integer of the current number of APICalls made that
@@ -218,6 +293,11 @@ type Forecast struct {
 	Daily      daily     `json:"daily"`
 	Alerts     []*alert  `json:"alerts"`
 	Flags      flags     `json:"flags"`
+
+	// Place is set by ForecastByPlace to record which geocoded location
+	// this Forecast was actually requested for. It is nil for forecasts
+	// requested directly by latitude/longitude.
+	Place *Place `json:"-"`
 }
 
 // ParseTimes will fill out all time.Time variables in a Forecast by
@@ -245,93 +325,142 @@ func (f *Forecast) ParseTimes() {
 	}
 }
 
-// Forecast requests a forecast from forecastio using the APIConn a.
-// lat and lon are float64s representing the latitude and longitude of
-// the location the forecast is for.
-// excludes is an array of strings for fields that are to be excluded
-// from the forecast, valid exludes are;
-//     currently, minutely, hourly, daily, alerts, flags
-// extendHourly is a boolean flag indicating whether to return hourly
-// data for 7 days rather than the default of 2 days.
-// Returns a pointer to a Forecast and an error. The two are mutually
-// exclusive in that one will always be nil.
-func (a *APIConn) Forecast(lat, lon float64, excludes []string, extendHourly bool) (*Forecast, error) {
+// CachedAt returns the time the data behind the most recent Forecast/
+// ForecastAtTime call was actually fetched from forecast.io, which may be
+// well before now if a Cache satisfied that call. It is the zero Time until
+// a call has been made; without a Cache it is the time of the most recent
+// network fetch.
+func (a *APIConn) CachedAt() time.Time {
+	a.RLock()
+	defer a.RUnlock()
+	return a.cachedAt
+}
+
+// cacheKey derives a cache key for query: the request URL with the API key
+// removed, so cache entries aren't keyed on (or leak) credentials.
+func (a *APIConn) cacheKey(query string) string {
+	return strings.Replace(query, a.apiKey, "redacted", 1)
+}
+
+// fetch performs the actual HTTP round trip for query, unmarshalling the
+// response body into a Forecast and recording the API call count reported
+// by forecast.io. It only takes the lock around writing the call counter
+// and cachedAt, not around the request itself, so concurrent
+// calls on the same APIConn can be in flight at once. If a Cache is
+// configured it is consulted first, and populated on every miss.
+func (a *APIConn) fetch(ctx context.Context, query string) (*Forecast, error) {
 	var forecast Forecast
+	key := a.cacheKey(query)
 
-	for _, ex := range excludes {
-		if ex == "" {
-			continue
-		}
-		if _, ok := excludesSet[ex]; !ok {
-			return nil, errors.New("Invalid exclude requested")
+	if a.cache != nil {
+		if body, fetchedAt, ok := a.cache.Get(key); ok {
+			if err := json.Unmarshal(body, &forecast); err != nil {
+				return nil, err
+			}
+			a.Lock()
+			a.cachedAt = fetchedAt
+			a.Unlock()
+			return &forecast, nil
 		}
 	}
-	query := fmt.Sprintf("%s/%s/%f,%f?units=%s&exclude=%s", baseURL, a.apiKey, lat, lon, a.units, strings.Join(excludes, ","))
-	if extendHourly {
-		query += "&extend=hourly"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	if a.userAgent != "" {
+		req.Header.Set("User-Agent", a.userAgent)
 	}
 
-	a.Lock()
-	defer a.Unlock()
-	response, err := http.Get(query)
+	response, err := a.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
+	defer response.Body.Close()
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
 		return nil, err
 	}
-	response.Body.Close()
+	fetchedAt := time.Now()
+	if a.cache != nil {
+		a.cache.Set(key, body, fetchedAt)
+	}
 	err = json.Unmarshal(body, &forecast)
+
+	a.Lock()
 	a.apiCalls, _ = strconv.Atoi(response.Header.Get("X-Forecast-API-Calls"))
-	return &forecast, err
+	a.cachedAt = fetchedAt
+	a.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return &forecast, nil
 }
 
-// ForecastAtTime requests a forecast from forecastio using the APIConn a.
-// for a specific point in time.
+// ForecastContext requests a forecast from forecastio using the APIConn a,
+// aborting the request if ctx is cancelled or times out.
+// lat and lon are float64s representing the latitude and longitude of
+// the location the forecast is for.
+// excludes is an array of strings for fields that are to be excluded
+// from the forecast, valid exludes are;
+//
+//	currently, minutely, hourly, daily, alerts, flags
+//
+// extendHourly is a boolean flag indicating whether to return hourly
+// data for 7 days rather than the default of 2 days.
+// Returns a pointer to a Forecast and an error. The two are mutually
+// exclusive in that one will always be nil.
+func (a *APIConn) ForecastContext(ctx context.Context, lat, lon float64, excludes []string, extendHourly bool) (*Forecast, error) {
+	return a.Do(ctx, ForecastRequest{
+		Latitude:     lat,
+		Longitude:    lon,
+		Excludes:     excludes,
+		ExtendHourly: extendHourly,
+	})
+}
+
+// Forecast is equivalent to ForecastContext with context.Background(), kept
+// for callers that don't need cancellation.
+func (a *APIConn) Forecast(lat, lon float64, excludes []string, extendHourly bool) (*Forecast, error) {
+	return a.ForecastContext(context.Background(), lat, lon, excludes, extendHourly)
+}
+
+// ForecastAtTimeContext requests a forecast from forecastio using the
+// APIConn a for a specific point in time, aborting the request if ctx is
+// cancelled or times out.
 // lat and lon are float64s representing the latitude and longitude of
 // the location the forecast is for.
 // date should be either a time.Time, an int/int64 of the unix time for
 // formatted as follows: [YYYY]-[MM]-[DD]T[HH]:[MM]:[SS] (with an optional
 // time zone formatted as Z for GMT time or {+,-}[HH][MM], for example;
-//     2013-05-06T12:00:00-0400
+//
+//	2013-05-06T12:00:00-0400
+//
 // the point in time requested or a string. If a string it must be either
 // a string representation of the unix time or
 // excludes is an array of strings for fields that are to be excluded
 // from the forecast, valid exludes are;
-//     currently, minutely, hourly, daily, alerts, flags
+//
+//	currently, minutely, hourly, daily, alerts, flags
+//
 // Returns a pointer to a Forecast and an error. The two are mutually
 // exclusive in that one will always be nil.
-func (a *APIConn) ForecastAtTime(lat, lon float64, date interface{}, excludes []string) (*Forecast, error) {
-	var forecast Forecast
-	var query string
-
-	for _, ex := range excludes {
-		if _, ok := excludesSet[ex]; !ok {
-			return nil, errors.New("Invalid exclude requested")
-		}
-	}
-	switch date.(type) {
-	case time.Time:
-		query = fmt.Sprintf("%s/%s/%f,%f,%d?units=%s&exclude=%s", baseURL, a.apiKey, lat, lon, date.(time.Time).Unix(), a.units, strings.Join(excludes, ","))
-	case int, int64:
-		query = fmt.Sprintf("%s/%s/%f,%f,%d?units=%s&exclude=%s", baseURL, a.apiKey, lat, lon, date.(int64), a.units, strings.Join(excludes, ","))
-	case string:
-		query = fmt.Sprintf("%s/%s/%f,%f,%s?units=%s&exclude=%s", baseURL, a.apiKey, lat, lon, date.(string), a.units, strings.Join(excludes, ","))
-	}
-
-	a.Lock()
-	defer a.Unlock()
-	response, err := http.Get(query)
+func (a *APIConn) ForecastAtTimeContext(ctx context.Context, lat, lon float64, date interface{}, excludes []string) (*Forecast, error) {
+	t, err := timeFromDate(date)
 	if err != nil {
 		return nil, err
 	}
-	body, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
-	}
-	response.Body.Close()
-	err = json.Unmarshal(body, &forecast)
-	a.apiCalls, _ = strconv.Atoi(response.Header.Get("X-Forecast-API-Calls"))
-	return &forecast, err
+	return a.Do(ctx, ForecastRequest{
+		Latitude:  lat,
+		Longitude: lon,
+		Time:      &t,
+		Excludes:  excludes,
+	})
+}
+
+// ForecastAtTime is equivalent to ForecastAtTimeContext with
+// context.Background(), kept for callers that don't need cancellation.
+func (a *APIConn) ForecastAtTime(lat, lon float64, date interface{}, excludes []string) (*Forecast, error) {
+	return a.ForecastAtTimeContext(context.Background(), lat, lon, date, excludes)
 }
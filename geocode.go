@@ -0,0 +1,146 @@
+package forecastio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const openMeteoGeocodingURL = "https://geocoding-api.open-meteo.com/v1/search"
+
+// Place is a single geocoding result: a named location together with the
+// coordinates needed to request a Forecast for it.
+type Place struct {
+	Name      string
+	Admin1    string
+	Country   string
+	Latitude  float64
+	Longitude float64
+	Timezone  string
+}
+
+// Geocoder resolves a free-text place name, eg; "Paris, France", to one or
+// more candidate Places.
+type Geocoder interface {
+	Lookup(ctx context.Context, query string) ([]Place, error)
+}
+
+// countryAbbreviations expands the administrative-area abbreviations users
+// commonly type after a place name (eg; "Paris, US") into the full names
+// Open-Meteo's geocoder reports in admin1/country, so both the abbreviated
+// and full forms resolve to the same result.
+var countryAbbreviations = map[string]string{
+	"US":  "United States",
+	"USA": "United States",
+	"UK":  "United Kingdom",
+}
+
+// OpenMeteoGeocoder implements Geocoder against Open-Meteo's geocoding API.
+type OpenMeteoGeocoder struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOpenMeteoGeocoder returns a Geocoder backed by Open-Meteo
+// (geocoding-api.open-meteo.com). It requires no API key.
+func NewOpenMeteoGeocoder() *OpenMeteoGeocoder {
+	return &OpenMeteoGeocoder{httpClient: http.DefaultClient, baseURL: openMeteoGeocodingURL}
+}
+
+type openMeteoGeocodingResponse struct {
+	Results []struct {
+		Name      string  `json:"name"`
+		Admin1    string  `json:"admin1"`
+		Country   string  `json:"country"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Timezone  string  `json:"timezone"`
+	} `json:"results"`
+}
+
+// Lookup implements Geocoder. If query ends in a comma-separated suffix
+// (eg; ", US", ", USA" or ", Texas"), that suffix is stripped before the
+// request is sent, expanded if it is a known abbreviation, and the results
+// are filtered down to those whose admin1 or country match it, so
+// "Paris, US" and "Paris, USA" both resolve to United States results such
+// as Paris, Texas rather than Paris, France.
+func (g *OpenMeteoGeocoder) Lookup(ctx context.Context, query string) ([]Place, error) {
+	name, suffix := splitAdminSuffix(query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s?name=%s&count=10", g.baseURL, url.QueryEscape(name)), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("forecastio: open-meteo geocoding request failed with status %s", resp.Status)
+	}
+
+	var parsed openMeteoGeocodingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	places := make([]Place, 0, len(parsed.Results))
+	for _, r := range parsed.Results {
+		if suffix != "" && !strings.EqualFold(r.Admin1, suffix) && !strings.EqualFold(r.Country, suffix) {
+			continue
+		}
+		places = append(places, Place{
+			Name:      r.Name,
+			Admin1:    r.Admin1,
+			Country:   r.Country,
+			Latitude:  r.Latitude,
+			Longitude: r.Longitude,
+			Timezone:  r.Timezone,
+		})
+	}
+	return places, nil
+}
+
+// splitAdminSuffix splits "Paris, US" into ("Paris", "United States"),
+// expanding any known abbreviation. Queries with no comma, or with a
+// suffix that isn't a known abbreviation, are returned with that suffix
+// verbatim so an already-expanded name like "Paris, United States" still
+// filters correctly.
+func splitAdminSuffix(query string) (name, suffix string) {
+	idx := strings.LastIndex(query, ",")
+	if idx == -1 {
+		return query, ""
+	}
+	name = strings.TrimSpace(query[:idx])
+	raw := strings.TrimSpace(query[idx+1:])
+	if expanded, ok := countryAbbreviations[strings.ToUpper(raw)]; ok {
+		return name, expanded
+	}
+	return name, raw
+}
+
+// ForecastByPlace resolves query via the APIConn's Geocoder (Open-Meteo by
+// default, see WithGeocoder) and requests a Forecast for the best-matching
+// Place, recording it on the returned Forecast's Place field.
+func (a *APIConn) ForecastByPlace(ctx context.Context, query string, excludes []string, extendHourly bool) (*Forecast, error) {
+	places, err := a.geocoder.Lookup(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(places) == 0 {
+		return nil, fmt.Errorf("forecastio: no place found for %q", query)
+	}
+
+	place := places[0]
+	f, err := a.ForecastContext(ctx, place.Latitude, place.Longitude, excludes, extendHourly)
+	if err != nil {
+		return nil, err
+	}
+	f.Place = &place
+	return f, nil
+}
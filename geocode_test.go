@@ -0,0 +1,81 @@
+package forecastio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSplitAdminSuffix(t *testing.T) {
+	tests := []struct {
+		query      string
+		wantName   string
+		wantSuffix string
+	}{
+		{"Paris", "Paris", ""},
+		{"Paris, US", "Paris", "United States"},
+		{"Paris, USA", "Paris", "United States"},
+		{"Paris, us", "Paris", "United States"},
+		{"London, UK", "London", "United Kingdom"},
+		{"Paris, United States", "Paris", "United States"},
+		{"Paris, Texas", "Paris", "Texas"},
+		{"  Paris  ,  US  ", "Paris", "United States"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			name, suffix := splitAdminSuffix(tt.query)
+			if name != tt.wantName || suffix != tt.wantSuffix {
+				t.Fatalf("splitAdminSuffix(%q) = (%q, %q), want (%q, %q)",
+					tt.query, name, suffix, tt.wantName, tt.wantSuffix)
+			}
+		})
+	}
+}
+
+func TestOpenMeteoGeocoderLookupFiltersBySuffix(t *testing.T) {
+	results := []struct {
+		Name      string  `json:"name"`
+		Admin1    string  `json:"admin1"`
+		Country   string  `json:"country"`
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+		Timezone  string  `json:"timezone"`
+	}{
+		{Name: "Paris", Admin1: "Ile-de-France", Country: "France", Latitude: 48.85, Longitude: 2.35},
+		{Name: "Paris", Admin1: "Texas", Country: "United States", Latitude: 33.66, Longitude: -95.55},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	}))
+	defer srv.Close()
+
+	g := &OpenMeteoGeocoder{httpClient: http.DefaultClient, baseURL: srv.URL}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantLen int
+	}{
+		{name: "no suffix returns all matches", query: "Paris", wantLen: 2},
+		{name: "US suffix filters to the US result", query: "Paris, US", wantLen: 1},
+		{name: "USA suffix filters to the US result", query: "Paris, USA", wantLen: 1},
+		{name: "France country filters to the France result", query: "Paris, France", wantLen: 1},
+		{name: "unmatched suffix filters out everything", query: "Paris, Germany", wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			places, err := g.Lookup(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("Lookup(%q) error = %v", tt.query, err)
+			}
+			if len(places) != tt.wantLen {
+				t.Fatalf("Lookup(%q) returned %d places, want %d", tt.query, len(places), tt.wantLen)
+			}
+		})
+	}
+}
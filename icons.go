@@ -0,0 +1,59 @@
+package forecastio
+
+// wmoWeather holds the icon and human-readable summary forecast.io would
+// have returned for a given WMO weather code (the table used by
+// Open-Meteo), split by day/night since several codes render differently
+// after dark.
+type wmoWeather struct {
+	icon, nightIcon string
+	summary         string
+}
+
+// wmoWeatherCodes maps Open-Meteo's "weathercode"/"weather_code" values
+// (WMO code table 4677) onto the icon/summary vocabulary the rest of this
+// package, and callers of it, already expect from forecast.io.
+var wmoWeatherCodes = map[int]wmoWeather{
+	0:  {"clear-day", "clear-night", "Clear sky"},
+	1:  {"clear-day", "clear-night", "Mainly clear"},
+	2:  {"partly-cloudy-day", "partly-cloudy-night", "Partly cloudy"},
+	3:  {"cloudy", "cloudy", "Overcast"},
+	45: {"fog", "fog", "Fog"},
+	48: {"fog", "fog", "Depositing rime fog"},
+	51: {"rain", "rain", "Light drizzle"},
+	53: {"rain", "rain", "Moderate drizzle"},
+	55: {"rain", "rain", "Dense drizzle"},
+	56: {"sleet", "sleet", "Light freezing drizzle"},
+	57: {"sleet", "sleet", "Dense freezing drizzle"},
+	61: {"rain", "rain", "Slight rain"},
+	63: {"rain", "rain", "Moderate rain"},
+	65: {"rain", "rain", "Heavy rain"},
+	66: {"sleet", "sleet", "Light freezing rain"},
+	67: {"sleet", "sleet", "Heavy freezing rain"},
+	71: {"snow", "snow", "Slight snow fall"},
+	73: {"snow", "snow", "Moderate snow fall"},
+	75: {"snow", "snow", "Heavy snow fall"},
+	77: {"snow", "snow", "Snow grains"},
+	80: {"rain", "rain", "Slight rain showers"},
+	81: {"rain", "rain", "Moderate rain showers"},
+	82: {"rain", "rain", "Violent rain showers"},
+	85: {"snow", "snow", "Slight snow showers"},
+	86: {"snow", "snow", "Heavy snow showers"},
+	95: {"thunderstorm", "thunderstorm", "Thunderstorm"},
+	96: {"thunderstorm", "thunderstorm", "Thunderstorm with slight hail"},
+	99: {"thunderstorm", "thunderstorm", "Thunderstorm with heavy hail"},
+}
+
+// iconForWMOCode translates an Open-Meteo weather code into the
+// icon/summary pair used throughout a Forecast. isDay is Open-Meteo's own
+// "is_day" flag; unknown codes fall back to "cloudy" rather than leaving
+// Icon empty.
+func iconForWMOCode(code int, isDay bool) (icon, summary string) {
+	w, ok := wmoWeatherCodes[code]
+	if !ok {
+		return "cloudy", "Unknown"
+	}
+	if isDay {
+		return w.icon, w.summary
+	}
+	return w.nightIcon, w.summary
+}
@@ -0,0 +1,94 @@
+package forecastio
+
+import "testing"
+
+func TestIconForWMOCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      int
+		isDay     bool
+		wantIcon  string
+		wantEmpty bool
+	}{
+		{name: "clear sky, day", code: 0, isDay: true, wantIcon: "clear-day"},
+		{name: "clear sky, night", code: 0, isDay: false, wantIcon: "clear-night"},
+		{name: "overcast is the same icon day or night", code: 3, isDay: false, wantIcon: "cloudy"},
+		{name: "thunderstorm with heavy hail", code: 99, isDay: true, wantIcon: "thunderstorm"},
+		{name: "unknown code falls back to cloudy", code: 12345, isDay: true, wantIcon: "cloudy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			icon, summary := iconForWMOCode(tt.code, tt.isDay)
+			if icon != tt.wantIcon {
+				t.Fatalf("iconForWMOCode(%d, %v) icon = %q, want %q", tt.code, tt.isDay, icon, tt.wantIcon)
+			}
+			if summary == "" {
+				t.Fatalf("iconForWMOCode(%d, %v) summary = %q, want non-empty", tt.code, tt.isDay, summary)
+			}
+		})
+	}
+}
+
+func TestIconForNWSText(t *testing.T) {
+	tests := []struct {
+		name      string
+		short     string
+		isDaytime bool
+		wantIcon  string
+	}{
+		{name: "sunny day", short: "Sunny", isDaytime: true, wantIcon: "clear-day"},
+		{name: "clear night", short: "Clear", isDaytime: false, wantIcon: "clear-night"},
+		{name: "partly cloudy day", short: "Partly Cloudy", isDaytime: true, wantIcon: "partly-cloudy-day"},
+		{name: "partly cloudy night", short: "Mostly Cloudy", isDaytime: false, wantIcon: "partly-cloudy-night"},
+		{name: "chance of rain showers", short: "Chance Rain Showers", isDaytime: true, wantIcon: "rain"},
+		{name: "snow", short: "Snow", isDaytime: true, wantIcon: "snow"},
+		{name: "freezing rain maps to sleet", short: "Freezing Rain", isDaytime: true, wantIcon: "sleet"},
+		{name: "thunderstorms", short: "Chance Thunderstorms", isDaytime: true, wantIcon: "thunderstorm"},
+		{name: "fog", short: "Patchy Fog", isDaytime: true, wantIcon: "fog"},
+		{name: "windy", short: "Windy", isDaytime: true, wantIcon: "wind"},
+		{name: "unrecognised text falls back to cloudy", short: "Blowing Dust", isDaytime: true, wantIcon: "cloudy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			icon, summary := iconForNWSText(tt.short, tt.isDaytime)
+			if icon != tt.wantIcon {
+				t.Fatalf("iconForNWSText(%q, %v) icon = %q, want %q", tt.short, tt.isDaytime, icon, tt.wantIcon)
+			}
+			if summary != tt.short {
+				t.Fatalf("iconForNWSText(%q, %v) summary = %q, want the original text back", tt.short, tt.isDaytime, summary)
+			}
+		})
+	}
+}
+
+func TestIconForBBCType(t *testing.T) {
+	tests := []struct {
+		name        string
+		weatherType int
+		wantIcon    string
+	}{
+		{name: "clear night", weatherType: 0, wantIcon: "clear-night"},
+		{name: "partly cloudy night", weatherType: 2, wantIcon: "partly-cloudy-night"},
+		{name: "sunny day", weatherType: 4, wantIcon: "clear-day"},
+		{name: "partly cloudy day", weatherType: 7, wantIcon: "partly-cloudy-day"},
+		{name: "light rain", weatherType: 11, wantIcon: "rain"},
+		{name: "fog", weatherType: 19, wantIcon: "fog"},
+		{name: "snow", weatherType: 24, wantIcon: "snow"},
+		{name: "thunderstorm", weatherType: 29, wantIcon: "thunderstorm"},
+		{name: "unknown code falls back to cloudy", weatherType: 999, wantIcon: "cloudy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			icon, summary := iconForBBCType(tt.weatherType, "some text")
+			if icon != tt.wantIcon {
+				t.Fatalf("iconForBBCType(%d) icon = %q, want %q", tt.weatherType, icon, tt.wantIcon)
+			}
+			if summary != "some text" {
+				t.Fatalf("iconForBBCType(%d) summary = %q, want the text passed in verbatim", tt.weatherType, summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package forecastio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const nwsBaseURL = "https://api.weather.gov"
+
+// NWSProvider implements Provider against the US National Weather Service's
+// api.weather.gov, covering locations within the United States. The NWS API
+// requires every request to identify the calling application.
+type NWSProvider struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+}
+
+// NewNWSProvider returns a Provider backed by api.weather.gov. userAgent is
+// sent on every request as required by the NWS API terms of service, and
+// should identify the application and a contact method, eg;
+//
+//	"myweatherapp.example (contact@example.com)"
+func NewNWSProvider(userAgent string) *NWSProvider {
+	return &NWSProvider{httpClient: http.DefaultClient, baseURL: nwsBaseURL, userAgent: userAgent}
+}
+
+func (p *NWSProvider) get(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	req.Header.Set("Accept", "application/geo+json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("forecastio: nws request to %s failed with status %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+type nwsPointsResponse struct {
+	Properties struct {
+		ForecastHourly string `json:"forecastHourly"`
+		Forecast       string `json:"forecast"`
+	} `json:"properties"`
+}
+
+type nwsForecastResponse struct {
+	Properties struct {
+		Periods []struct {
+			StartTime                  time.Time `json:"startTime"`
+			IsDaytime                  bool      `json:"isDaytime"`
+			Temperature                float64   `json:"temperature"`
+			WindSpeed                  string    `json:"windSpeed"`
+			WindDirection              string    `json:"windDirection"`
+			ShortForecast              string    `json:"shortForecast"`
+			ProbabilityOfPrecipitation struct {
+				Value *float64 `json:"value"`
+			} `json:"probabilityOfPrecipitation"`
+		} `json:"periods"`
+	} `json:"properties"`
+}
+
+// gridPoint resolves lat, lon to the NWS gridpoint URLs needed for the
+// hourly and daily forecast endpoints, per the documented
+// /points/{lat},{lon} -> /gridpoints/... two-step lookup.
+func (p *NWSProvider) gridPoint(ctx context.Context, lat, lon float64) (*nwsPointsResponse, error) {
+	var points nwsPointsResponse
+	url := fmt.Sprintf("%s/points/%.4f,%.4f", p.baseURL, lat, lon)
+	if err := p.get(ctx, url, &points); err != nil {
+		return nil, err
+	}
+	return &points, nil
+}
+
+// toForecast builds a Forecast from the hourly and daily NWS responses.
+// Either may be nil when the corresponding section was excluded and so was
+// never fetched; excluded additionally controls whether the fetched hourly
+// periods populate Currently/Hourly.Data, since one hourly request serves
+// both unless "currently" and "hourly" are both excluded.
+func (p *NWSProvider) toForecast(lat, lon float64, hourly, daily *nwsForecastResponse, excluded map[string]bool) *Forecast {
+	f := &Forecast{Latitude: lat, Longitude: lon}
+
+	if hourly != nil {
+		var hourlyData []*hourData
+		for _, period := range hourly.Properties.Periods {
+			icon, summary := iconForNWSText(period.ShortForecast, period.IsDaytime)
+			var precipProbability float64
+			if v := period.ProbabilityOfPrecipitation.Value; v != nil {
+				precipProbability = *v / 100
+			}
+			hourlyData = append(hourlyData, &hourData{
+				Time:                     period.StartTime,
+				TimeUnix:                 period.StartTime.Unix(),
+				Summary:                  summary,
+				Icon:                     icon,
+				Temperature:              period.Temperature,
+				PrecipitationProbability: precipProbability,
+				WindSpeed:                parseLeadingFloat(period.WindSpeed),
+				WindBearing:              compassToBearing(period.WindDirection),
+			})
+		}
+
+		if len(hourlyData) > 0 && !excluded["currently"] {
+			first := hourlyData[0]
+			f.Currently = currently{
+				TimeUnix:                 first.TimeUnix,
+				Time:                     first.Time,
+				Summary:                  first.Summary,
+				Icon:                     first.Icon,
+				Temperature:              first.Temperature,
+				PrecipitationProbability: first.PrecipitationProbability,
+				WindSpeed:                first.WindSpeed,
+				WindBearing:              first.WindBearing,
+			}
+		}
+		if !excluded["hourly"] {
+			f.Hourly.Data = hourlyData
+			if len(hourlyData) > 0 {
+				f.Hourly.Icon, f.Hourly.Summary = hourlyData[0].Icon, hourlyData[0].Summary
+			}
+		}
+	}
+
+	if daily != nil {
+		f.Daily.Data = nwsDailyData(daily)
+		if len(f.Daily.Data) > 0 {
+			f.Daily.Icon, f.Daily.Summary = f.Daily.Data[0].Icon, f.Daily.Data[0].Summary
+		}
+	}
+
+	return f
+}
+
+// nwsDailyData collapses the day/night period pairs NWS's daily forecast
+// endpoint returns into one dayData per calendar date, taking the daytime
+// period's temperature as the high and the following night period's as
+// the low.
+func nwsDailyData(daily *nwsForecastResponse) []*dayData {
+	type accum struct {
+		date          time.Time
+		tempMax       float64
+		tempMin       float64
+		icon, summary string
+	}
+	byDate := make(map[string]*accum)
+	var order []string
+
+	for _, period := range daily.Properties.Periods {
+		key := period.StartTime.Format("2006-01-02")
+		a, ok := byDate[key]
+		if !ok {
+			a = &accum{date: period.StartTime.Truncate(24 * time.Hour)}
+			byDate[key] = a
+			order = append(order, key)
+		}
+		icon, summary := iconForNWSText(period.ShortForecast, period.IsDaytime)
+		if period.IsDaytime {
+			a.tempMax = period.Temperature
+			a.icon, a.summary = icon, summary
+		} else {
+			a.tempMin = period.Temperature
+			if a.icon == "" {
+				a.icon, a.summary = icon, summary
+			}
+		}
+	}
+
+	days := make([]*dayData, 0, len(order))
+	for _, key := range order {
+		a := byDate[key]
+		days = append(days, &dayData{
+			Time:           a.date,
+			TimeUnix:       a.date.Unix(),
+			Summary:        a.summary,
+			Icon:           a.icon,
+			TemperatureMax: a.tempMax,
+			TemperatureMin: a.tempMin,
+		})
+	}
+	return days
+}
+
+// Forecast implements Provider. The hourly gridpoint endpoint is skipped
+// entirely if both "currently" and "hourly" are excluded, and the daily
+// endpoint is skipped if "daily" is excluded.
+func (p *NWSProvider) Forecast(ctx context.Context, lat, lon float64, opts ForecastOptions) (*Forecast, error) {
+	excluded := excludeSet(opts.Excludes)
+
+	points, err := p.gridPoint(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+
+	var hourly *nwsForecastResponse
+	if !excluded["currently"] || !excluded["hourly"] {
+		hourly = &nwsForecastResponse{}
+		if err := p.get(ctx, points.Properties.ForecastHourly, hourly); err != nil {
+			return nil, err
+		}
+	}
+
+	var daily *nwsForecastResponse
+	if !excluded["daily"] {
+		daily = &nwsForecastResponse{}
+		if err := p.get(ctx, points.Properties.Forecast, daily); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.toForecast(lat, lon, hourly, daily, excluded), nil
+}
+
+// ForecastAtTime implements Provider by requesting the normal hourly
+// forecast and picking out the period whose StartTime is closest to t.
+func (p *NWSProvider) ForecastAtTime(ctx context.Context, lat, lon float64, t time.Time, opts ForecastOptions) (*Forecast, error) {
+	f, err := p.Forecast(ctx, lat, lon, opts)
+	if err != nil {
+		return nil, err
+	}
+	if closest := closestHourData(f.Hourly.Data, t); closest != nil {
+		f.Currently = currentlyFromHourData(closest)
+	}
+	return f, nil
+}
+
+// iconForNWSText maps the free-text shortForecast NWS returns onto the same
+// icon vocabulary used elsewhere in this package, since api.weather.gov has
+// no numeric weather code of its own.
+func iconForNWSText(short string, isDaytime bool) (icon, summary string) {
+	text := strings.ToLower(short)
+	dayNight := func(day, night string) string {
+		if isDaytime {
+			return day
+		}
+		return night
+	}
+	switch {
+	case strings.Contains(text, "thunderstorm"):
+		return "thunderstorm", short
+	case strings.Contains(text, "snow"), strings.Contains(text, "blizzard"):
+		return "snow", short
+	case strings.Contains(text, "sleet"), strings.Contains(text, "ice"), strings.Contains(text, "freezing"):
+		return "sleet", short
+	case strings.Contains(text, "rain"), strings.Contains(text, "showers"), strings.Contains(text, "drizzle"):
+		return "rain", short
+	case strings.Contains(text, "fog"), strings.Contains(text, "haze"):
+		return "fog", short
+	case strings.Contains(text, "wind"):
+		return "wind", short
+	case strings.Contains(text, "clear"), strings.Contains(text, "sunny"):
+		return dayNight("clear-day", "clear-night"), short
+	case strings.Contains(text, "cloud"), strings.Contains(text, "overcast"):
+		return dayNight("partly-cloudy-day", "partly-cloudy-night"), short
+	default:
+		return "cloudy", short
+	}
+}
+
+// parseLeadingFloat extracts the leading number from strings like "10 mph"
+// or "5 to 10 mph", returning the first value found.
+func parseLeadingFloat(s string) float64 {
+	var value float64
+	fmt.Sscanf(s, "%f", &value)
+	return value
+}
+
+// compassToBearing converts a 16-point compass direction (eg; "NNE") as
+// returned by NWS into a bearing in degrees.
+func compassToBearing(direction string) float64 {
+	points := []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	for i, p := range points {
+		if p == strings.ToUpper(strings.TrimSpace(direction)) {
+			return float64(i) * 22.5
+		}
+	}
+	return 0
+}
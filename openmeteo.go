@@ -0,0 +1,245 @@
+package forecastio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const openMeteoBaseURL = "https://api.open-meteo.com/v1/forecast"
+
+// OpenMeteoProvider implements Provider against the free Open-Meteo API,
+// one of the forecast.io-compatible replacements this package supports.
+// It requires no API key.
+type OpenMeteoProvider struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOpenMeteoProvider returns a Provider backed by Open-Meteo
+// (open-meteo.com).
+func NewOpenMeteoProvider() *OpenMeteoProvider {
+	return &OpenMeteoProvider{httpClient: http.DefaultClient, baseURL: openMeteoBaseURL}
+}
+
+type openMeteoResponse struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone"`
+	Current   struct {
+		Time              string  `json:"time"`
+		Temperature2m     float64 `json:"temperature_2m"`
+		ApparentTemp      float64 `json:"apparent_temperature"`
+		Humidity          float64 `json:"relative_humidity_2m"`
+		PrecipProbability float64 `json:"precipitation_probability"`
+		Precipitation     float64 `json:"precipitation"`
+		WeatherCode       int     `json:"weather_code"`
+		CloudCover        float64 `json:"cloud_cover"`
+		Pressure          float64 `json:"pressure_msl"`
+		WindSpeed         float64 `json:"wind_speed_10m"`
+		WindBearing       float64 `json:"wind_direction_10m"`
+		Visibility        float64 `json:"visibility"`
+		IsDay             int     `json:"is_day"`
+	} `json:"current"`
+	Hourly struct {
+		Time                     []string  `json:"time"`
+		Temperature2m            []float64 `json:"temperature_2m"`
+		ApparentTemperature      []float64 `json:"apparent_temperature"`
+		PrecipitationProbability []float64 `json:"precipitation_probability"`
+		Precipitation            []float64 `json:"precipitation"`
+		WeatherCode              []int     `json:"weather_code"`
+		Humidity                 []float64 `json:"relative_humidity_2m"`
+		Pressure                 []float64 `json:"pressure_msl"`
+		WindSpeed                []float64 `json:"wind_speed_10m"`
+		WindBearing              []float64 `json:"wind_direction_10m"`
+		IsDay                    []int     `json:"is_day"`
+	} `json:"hourly"`
+	Daily struct {
+		Time                     []string  `json:"time"`
+		WeatherCode              []int     `json:"weather_code"`
+		TemperatureMax           []float64 `json:"temperature_2m_max"`
+		TemperatureMin           []float64 `json:"temperature_2m_min"`
+		PrecipitationProbability []float64 `json:"precipitation_probability_max"`
+		PrecipitationSum         []float64 `json:"precipitation_sum"`
+		Sunrise                  []string  `json:"sunrise"`
+		Sunset                   []string  `json:"sunset"`
+	} `json:"daily"`
+}
+
+func (p *OpenMeteoProvider) request(ctx context.Context, lat, lon float64, opts ForecastOptions) (*openMeteoResponse, error) {
+	excluded := excludeSet(opts.Excludes)
+
+	query := fmt.Sprintf("%s?latitude=%f&longitude=%f&timezone=auto", p.baseURL, lat, lon)
+	if !excluded["currently"] {
+		query += "&current=temperature_2m,apparent_temperature,relative_humidity_2m,precipitation_probability,precipitation,weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,visibility,is_day"
+	}
+	if !excluded["hourly"] {
+		query += "&hourly=temperature_2m,apparent_temperature,relative_humidity_2m,precipitation_probability,precipitation,weather_code,pressure_msl,wind_speed_10m,wind_direction_10m,is_day"
+	}
+	if !excluded["daily"] {
+		query += "&daily=weather_code,temperature_2m_max,temperature_2m_min,precipitation_probability_max,precipitation_sum,sunrise,sunset"
+	}
+	if opts.ExtendHourly {
+		query += "&forecast_days=7"
+	} else {
+		query += "&forecast_days=2"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("forecastio: open-meteo request failed with status %s", resp.Status)
+	}
+
+	var parsed openMeteoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// sameLength reports whether every length in lens equals n, so the hourly
+// and daily parallel arrays Open-Meteo returns can be validated before
+// they're indexed together.
+func sameLength(n int, lens ...int) bool {
+	for _, l := range lens {
+		if l != n {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *OpenMeteoProvider) toForecast(r *openMeteoResponse) (*Forecast, error) {
+	icon, summary := iconForWMOCode(r.Current.WeatherCode, r.Current.IsDay == 1)
+	f := &Forecast{
+		Latitude:  r.Latitude,
+		Longitude: r.Longitude,
+		Timezone:  r.Timezone,
+		Currently: currently{
+			Summary:                  summary,
+			Icon:                     icon,
+			Temperature:              r.Current.Temperature2m,
+			ApparentTemperature:      r.Current.ApparentTemp,
+			Humidity:                 r.Current.Humidity / 100,
+			PrecipitationIntensity:   r.Current.Precipitation,
+			PrecipitationProbability: r.Current.PrecipProbability / 100,
+			WindSpeed:                r.Current.WindSpeed,
+			WindBearing:              r.Current.WindBearing,
+			Visibility:               r.Current.Visibility,
+			CloudCover:               r.Current.CloudCover / 100,
+			Pressure:                 r.Current.Pressure,
+		},
+	}
+	if t, err := time.Parse("2006-01-02T15:04", r.Current.Time); err == nil {
+		f.Currently.Time = t
+		f.Currently.TimeUnix = t.Unix()
+	}
+
+	n := len(r.Hourly.Time)
+	if !sameLength(n,
+		len(r.Hourly.Temperature2m), len(r.Hourly.ApparentTemperature),
+		len(r.Hourly.PrecipitationProbability), len(r.Hourly.Precipitation),
+		len(r.Hourly.WeatherCode), len(r.Hourly.Humidity), len(r.Hourly.Pressure),
+		len(r.Hourly.WindSpeed), len(r.Hourly.WindBearing), len(r.Hourly.IsDay),
+	) {
+		return nil, fmt.Errorf("forecastio: open-meteo hourly response arrays have mismatched lengths")
+	}
+	for i, ts := range r.Hourly.Time {
+		t, err := time.Parse("2006-01-02T15:04", ts)
+		if err != nil {
+			continue
+		}
+		hIcon, hSummary := iconForWMOCode(r.Hourly.WeatherCode[i], r.Hourly.IsDay[i] == 1)
+		f.Hourly.Data = append(f.Hourly.Data, &hourData{
+			Time:                     t,
+			TimeUnix:                 t.Unix(),
+			Summary:                  hSummary,
+			Icon:                     hIcon,
+			Temperature:              r.Hourly.Temperature2m[i],
+			ApparentTemperature:      r.Hourly.ApparentTemperature[i],
+			Humidity:                 r.Hourly.Humidity[i] / 100,
+			PrecipitationProbability: r.Hourly.PrecipitationProbability[i] / 100,
+			PrecipitationIntensity:   r.Hourly.Precipitation[i],
+			WindSpeed:                r.Hourly.WindSpeed[i],
+			WindBearing:              r.Hourly.WindBearing[i],
+			Pressure:                 r.Hourly.Pressure[i],
+		})
+	}
+	if len(f.Hourly.Data) > 0 {
+		f.Hourly.Icon, f.Hourly.Summary = f.Hourly.Data[0].Icon, f.Hourly.Data[0].Summary
+	}
+
+	nDaily := len(r.Daily.Time)
+	if !sameLength(nDaily,
+		len(r.Daily.WeatherCode), len(r.Daily.TemperatureMax), len(r.Daily.TemperatureMin),
+		len(r.Daily.PrecipitationProbability), len(r.Daily.PrecipitationSum),
+		len(r.Daily.Sunrise), len(r.Daily.Sunset),
+	) {
+		return nil, fmt.Errorf("forecastio: open-meteo daily response arrays have mismatched lengths")
+	}
+	for i, ds := range r.Daily.Time {
+		t, err := time.Parse("2006-01-02", ds)
+		if err != nil {
+			continue
+		}
+		dIcon, dSummary := iconForWMOCode(r.Daily.WeatherCode[i], true)
+		d := &dayData{
+			Time:                     t,
+			TimeUnix:                 t.Unix(),
+			Summary:                  dSummary,
+			Icon:                     dIcon,
+			TemperatureMax:           r.Daily.TemperatureMax[i],
+			TemperatureMin:           r.Daily.TemperatureMin[i],
+			PrecipitationProbability: r.Daily.PrecipitationProbability[i] / 100,
+			PrecipitationIntensity:   r.Daily.PrecipitationSum[i],
+		}
+		if sr, err := time.Parse("2006-01-02T15:04", r.Daily.Sunrise[i]); err == nil {
+			d.Sunrise, d.SunriseUnix = sr, sr.Unix()
+		}
+		if ss, err := time.Parse("2006-01-02T15:04", r.Daily.Sunset[i]); err == nil {
+			d.Sunset, d.SunsetUnix = ss, ss.Unix()
+		}
+		f.Daily.Data = append(f.Daily.Data, d)
+	}
+	if len(f.Daily.Data) > 0 {
+		f.Daily.Icon, f.Daily.Summary = f.Daily.Data[0].Icon, f.Daily.Data[0].Summary
+	}
+
+	return f, nil
+}
+
+// Forecast implements Provider.
+func (p *OpenMeteoProvider) Forecast(ctx context.Context, lat, lon float64, opts ForecastOptions) (*Forecast, error) {
+	resp, err := p.request(ctx, lat, lon, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p.toForecast(resp)
+}
+
+// ForecastAtTime implements Provider. Open-Meteo has no dedicated
+// point-in-time endpoint, so this requests the normal forecast window and
+// picks out the hourly entry closest to t; a t outside the returned range
+// gets the nearest entry at its edge. The current conditions are left as
+// they are only when no hourly entries were returned (eg; hourly excluded).
+func (p *OpenMeteoProvider) ForecastAtTime(ctx context.Context, lat, lon float64, t time.Time, opts ForecastOptions) (*Forecast, error) {
+	opts.ExtendHourly = true
+	f, err := p.Forecast(ctx, lat, lon, opts)
+	if err != nil {
+		return nil, err
+	}
+	if closest := closestHourData(f.Hourly.Data, t); closest != nil {
+		f.Currently = currentlyFromHourData(closest)
+	}
+	return f, nil
+}
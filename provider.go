@@ -0,0 +1,117 @@
+package forecastio
+
+import (
+	"context"
+	"time"
+)
+
+// ForecastOptions controls which sections of a Forecast are populated and
+// how much hourly data is returned. It is the common request shape shared
+// by every Provider implementation, independent of how each backend's
+// native API happens to be parameterised.
+type ForecastOptions struct {
+	// Excludes lists sections to omit from the returned Forecast, valid
+	// values are the same as those accepted by APIConn.Forecast;
+	//     currently, minutely, hourly, daily, alerts, flags
+	// Backends that have no native concept of a section (eg; minutely,
+	// alerts) silently ignore excludes that don't apply to them.
+	Excludes []string
+	// ExtendHourly requests hourly data further into the future than a
+	// backend's default window, where supported.
+	ExtendHourly bool
+}
+
+// Provider is implemented by anything that can turn a latitude/longitude,
+// and optionally a point in time, into a *Forecast. It exists so that
+// callers can depend on weather data without caring whether it ultimately
+// comes from forecast.io, Open-Meteo, the US National Weather Service or
+// the BBC, all of which are mapped onto the same Forecast/currently/
+// hourly/daily shape.
+type Provider interface {
+	// Forecast returns the current forecast for lat, lon.
+	Forecast(ctx context.Context, lat, lon float64, opts ForecastOptions) (*Forecast, error)
+	// ForecastAtTime returns the forecast for lat, lon at t, where the
+	// backend supports historical/future point-in-time lookups.
+	ForecastAtTime(ctx context.Context, lat, lon float64, t time.Time, opts ForecastOptions) (*Forecast, error)
+}
+
+// excludeSet turns an Excludes slice into a lookup set, so backends that
+// natively support dropping a section can check membership in O(1).
+func excludeSet(excludes []string) map[string]bool {
+	set := make(map[string]bool, len(excludes))
+	for _, e := range excludes {
+		set[e] = true
+	}
+	return set
+}
+
+// absDuration returns the absolute value of d, for comparing how far two
+// times are apart regardless of which one comes first.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// closestHourData returns whichever entry in hours has a Time closest to
+// t, or nil if hours is empty. Open-Meteo, NWS and BBC all implement
+// Provider.ForecastAtTime by requesting their normal hourly window and
+// picking out the entry nearest the requested time, since none of them
+// have a dedicated point-in-time endpoint.
+func closestHourData(hours []*hourData, t time.Time) *hourData {
+	var closest *hourData
+	for _, h := range hours {
+		if closest == nil || absDuration(h.Time.Sub(t)) < absDuration(closest.Time.Sub(t)) {
+			closest = h
+		}
+	}
+	return closest
+}
+
+// currentlyFromHourData copies an hourData entry into the currently shape,
+// for backends whose ForecastAtTime rebuilds Currently from the closest
+// hourly entry. Fields hourData doesn't populate for a given backend are
+// simply left at their zero value.
+func currentlyFromHourData(h *hourData) currently {
+	return currently{
+		TimeUnix:                 h.TimeUnix,
+		Time:                     h.Time,
+		Summary:                  h.Summary,
+		Icon:                     h.Icon,
+		PrecipitationIntensity:   h.PrecipitationIntensity,
+		PrecipitationProbability: h.PrecipitationProbability,
+		Temperature:              h.Temperature,
+		ApparentTemperature:      h.ApparentTemperature,
+		DewPoint:                 h.DewPoint,
+		Humidity:                 h.Humidity,
+		WindSpeed:                h.WindSpeed,
+		WindBearing:              h.WindBearing,
+		Visibility:               h.Visibility,
+		CloudCover:               h.CloudCover,
+		Pressure:                 h.Pressure,
+		Ozone:                    h.Ozone,
+	}
+}
+
+// legacyProvider adapts an *APIConn, which predates the Provider interface
+// and carries its own excludes/units conventions from the forecast.io API,
+// onto the common Provider interface.
+type legacyProvider struct {
+	conn *APIConn
+}
+
+func (l legacyProvider) Forecast(ctx context.Context, lat, lon float64, opts ForecastOptions) (*Forecast, error) {
+	return l.conn.ForecastContext(ctx, lat, lon, opts.Excludes, opts.ExtendHourly)
+}
+
+func (l legacyProvider) ForecastAtTime(ctx context.Context, lat, lon float64, t time.Time, opts ForecastOptions) (*Forecast, error) {
+	return l.conn.ForecastAtTimeContext(ctx, lat, lon, t, opts.Excludes)
+}
+
+// AsProvider adapts a onto the Provider interface, so that a forecast.io
+// connection can be used anywhere a Provider is expected, alongside the
+// Open-Meteo, NWS and BBC backends.
+func (a *APIConn) AsProvider() Provider {
+	return legacyProvider{conn: a}
+}
@@ -0,0 +1,255 @@
+package forecastio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// openMeteoTestServer returns an httptest server serving a fixed
+// openMeteoResponse with three hourly entries an hour apart, so tests can
+// request a time between two of them.
+func openMeteoTestServer(t *testing.T, base time.Time) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := openMeteoResponse{}
+		resp.Current.Time = base.Format("2006-01-02T15:04")
+		if r.URL.Query().Get("hourly") == "" {
+			// Mirrors the real API: omitting &hourly=... from the
+			// request yields no hourly arrays in the response.
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		for i := 0; i < 3; i++ {
+			ts := base.Add(time.Duration(i) * time.Hour).Format("2006-01-02T15:04")
+			resp.Hourly.Time = append(resp.Hourly.Time, ts)
+			resp.Hourly.Temperature2m = append(resp.Hourly.Temperature2m, float64(i))
+			resp.Hourly.ApparentTemperature = append(resp.Hourly.ApparentTemperature, float64(i))
+			resp.Hourly.PrecipitationProbability = append(resp.Hourly.PrecipitationProbability, 0)
+			resp.Hourly.Precipitation = append(resp.Hourly.Precipitation, 0)
+			resp.Hourly.WeatherCode = append(resp.Hourly.WeatherCode, 0)
+			resp.Hourly.Humidity = append(resp.Hourly.Humidity, 0)
+			resp.Hourly.Pressure = append(resp.Hourly.Pressure, 0)
+			resp.Hourly.WindSpeed = append(resp.Hourly.WindSpeed, 0)
+			resp.Hourly.WindBearing = append(resp.Hourly.WindBearing, 0)
+			resp.Hourly.IsDay = append(resp.Hourly.IsDay, 1)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestOpenMeteoForecastAtTimePicksNearestHour(t *testing.T) {
+	base := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	srv := openMeteoTestServer(t, base)
+	defer srv.Close()
+	p := &OpenMeteoProvider{httpClient: http.DefaultClient, baseURL: srv.URL}
+
+	// 10:50 is 50 minutes from the 10:00 entry and 10 minutes from 11:00.
+	f, err := p.ForecastAtTime(context.Background(), 0, 0, base.Add(50*time.Minute), ForecastOptions{})
+	if err != nil {
+		t.Fatalf("ForecastAtTime() error = %v", err)
+	}
+	if f.Currently.Temperature != 1 {
+		t.Fatalf("Currently.Temperature = %v, want 1 (the 11:00 entry)", f.Currently.Temperature)
+	}
+}
+
+func TestOpenMeteoForecastAtTimeOutsideWindowPicksClosestEdge(t *testing.T) {
+	base := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	srv := openMeteoTestServer(t, base)
+	defer srv.Close()
+	p := &OpenMeteoProvider{httpClient: http.DefaultClient, baseURL: srv.URL}
+
+	f, err := p.ForecastAtTime(context.Background(), 0, 0, base.Add(-10*time.Hour), ForecastOptions{})
+	if err != nil {
+		t.Fatalf("ForecastAtTime() error = %v", err)
+	}
+	if f.Currently.Temperature != 0 {
+		t.Fatalf("Currently.Temperature = %v, want 0 (the 10:00 entry, the nearest edge)", f.Currently.Temperature)
+	}
+}
+
+func TestOpenMeteoForecastAtTimeFallsBackWhenHourlyExcluded(t *testing.T) {
+	base := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	srv := openMeteoTestServer(t, base)
+	defer srv.Close()
+	p := &OpenMeteoProvider{httpClient: http.DefaultClient, baseURL: srv.URL}
+
+	f, err := p.ForecastAtTime(context.Background(), 0, 0, base, ForecastOptions{Excludes: []string{"hourly"}})
+	if err != nil {
+		t.Fatalf("ForecastAtTime() error = %v", err)
+	}
+	// The test server always returns current conditions regardless of
+	// excludes, so Currently should be left as Forecast() populated it
+	// rather than rebuilt from a (nonexistent) closest hourly entry.
+	if f.Currently.Time.Format("2006-01-02T15:04") != base.Format("2006-01-02T15:04") {
+		t.Fatalf("Currently.Time = %v, want it untouched from Forecast()'s own Currently", f.Currently.Time)
+	}
+}
+
+func TestNWSForecastAtTimePicksNearestHour(t *testing.T) {
+	base := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	periods := []string{
+		nwsPeriodJSON(base, true, 0, "Sunny"),
+		nwsPeriodJSON(base.Add(time.Hour), true, 1, "Sunny"),
+		nwsPeriodJSON(base.Add(2*time.Hour), true, 2, "Sunny"),
+	}
+	hourlyBody := `{"properties":{"periods":[` + joinJSON(periods) + `]}}`
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/points/0.0000,0.0000":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"properties": map[string]string{
+					"forecastHourly": srv.URL + "/hourly",
+					"forecast":       srv.URL + "/daily",
+				},
+			})
+		case "/hourly":
+			w.Write([]byte(hourlyBody))
+		case "/daily":
+			w.Write([]byte(`{"properties":{"periods":[]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p := &NWSProvider{httpClient: http.DefaultClient, baseURL: srv.URL, userAgent: "test"}
+
+	f, err := p.ForecastAtTime(context.Background(), 0, 0, base.Add(50*time.Minute), ForecastOptions{})
+	if err != nil {
+		t.Fatalf("ForecastAtTime() error = %v", err)
+	}
+	if f.Currently.Temperature != 1 {
+		t.Fatalf("Currently.Temperature = %v, want 1 (the 11:00 period)", f.Currently.Temperature)
+	}
+}
+
+func TestNWSForecastAtTimeFallsBackWhenHourlyExcluded(t *testing.T) {
+	base := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	hourlyBody := `{"properties":{"periods":[` + nwsPeriodJSON(base, true, 42, "Sunny") + `]}}`
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/points/0.0000,0.0000":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"properties": map[string]string{
+					"forecastHourly": srv.URL + "/hourly",
+					"forecast":       srv.URL + "/daily",
+				},
+			})
+		case "/hourly":
+			w.Write([]byte(hourlyBody))
+		case "/daily":
+			w.Write([]byte(`{"properties":{"periods":[]}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	p := &NWSProvider{httpClient: http.DefaultClient, baseURL: srv.URL, userAgent: "test"}
+
+	f, err := p.ForecastAtTime(context.Background(), 0, 0, base, ForecastOptions{Excludes: []string{"hourly"}})
+	if err != nil {
+		t.Fatalf("ForecastAtTime() error = %v", err)
+	}
+	// currently isn't excluded, so Forecast() still populates it from the
+	// first hourly period even though Hourly.Data itself stays empty;
+	// ForecastAtTime has nothing to pick a closer entry from and must
+	// leave it alone.
+	if f.Currently.Temperature != 42 {
+		t.Fatalf("Currently.Temperature = %v, want 42 (untouched from Forecast()'s own Currently)", f.Currently.Temperature)
+	}
+}
+
+func joinJSON(items []string) string {
+	out := ""
+	for i, item := range items {
+		if i > 0 {
+			out += ","
+		}
+		out += item
+	}
+	return out
+}
+
+func bbcReportJSON(date, slot string, weatherType, tempC int, precipPercent string) bbcReport {
+	return bbcReport{
+		LocalDate: date, Timeslot: slot,
+		WeatherType: weatherType, WeatherTypeText: "Sunny", TemperatureC: tempC,
+		PrecipitationProbabilityInPercent: precipPercent,
+	}
+}
+
+func TestBBCForecastAtTimePicksNearestHour(t *testing.T) {
+	resp := bbcResponse{}
+	resp.Forecasts = append(resp.Forecasts, struct {
+		Summary struct {
+			Report bbcReport `json:"report"`
+		} `json:"summary"`
+		Detailed struct {
+			ReportList []bbcReport `json:"reportList"`
+		} `json:"detailed"`
+	}{})
+	resp.Forecasts[0].Summary.Report = bbcReportJSON("2026-07-27", "10:00", 4, 0, "0")
+	resp.Forecasts[0].Detailed.ReportList = []bbcReport{
+		bbcReportJSON("2026-07-27", "10:00", 4, 0, "0"),
+		bbcReportJSON("2026-07-27", "11:00", 4, 1, "0"),
+		bbcReportJSON("2026-07-27", "12:00", 4, 2, "0"),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	p := &BBCProvider{httpClient: http.DefaultClient, baseURL: srv.URL, locationID: "test"}
+
+	base := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	f, err := p.ForecastAtTime(context.Background(), 0, 0, base.Add(50*time.Minute), ForecastOptions{})
+	if err != nil {
+		t.Fatalf("ForecastAtTime() error = %v", err)
+	}
+	if f.Currently.Temperature != 1 {
+		t.Fatalf("Currently.Temperature = %v, want 1 (the 11:00 report)", f.Currently.Temperature)
+	}
+}
+
+func TestBBCForecastAtTimeFallsBackWhenHourlyExcluded(t *testing.T) {
+	resp := bbcResponse{}
+	resp.Forecasts = append(resp.Forecasts, struct {
+		Summary struct {
+			Report bbcReport `json:"report"`
+		} `json:"summary"`
+		Detailed struct {
+			ReportList []bbcReport `json:"reportList"`
+		} `json:"detailed"`
+	}{})
+	resp.Forecasts[0].Summary.Report = bbcReportJSON("2026-07-27", "10:00", 4, 42, "0")
+	resp.Forecasts[0].Detailed.ReportList = []bbcReport{
+		bbcReportJSON("2026-07-27", "10:00", 4, 42, "0"),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	p := &BBCProvider{httpClient: http.DefaultClient, baseURL: srv.URL, locationID: "test"}
+
+	base := time.Date(2026, 7, 27, 10, 0, 0, 0, time.UTC)
+	f, err := p.ForecastAtTime(context.Background(), 0, 0, base, ForecastOptions{Excludes: []string{"hourly"}})
+	if err != nil {
+		t.Fatalf("ForecastAtTime() error = %v", err)
+	}
+	if f.Currently.Temperature != 42 {
+		t.Fatalf("Currently.Temperature = %v, want 42 (untouched from Forecast()'s own Currently)", f.Currently.Temperature)
+	}
+}
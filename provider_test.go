@@ -0,0 +1,222 @@
+package forecastio
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenMeteoToForecast(t *testing.T) {
+	r := &openMeteoResponse{Latitude: 51.5, Longitude: -0.12, Timezone: "Europe/London"}
+	r.Current.Time = "2026-07-27T12:00"
+	r.Current.Temperature2m = 20
+	r.Current.WeatherCode = 0
+	r.Current.IsDay = 1
+
+	r.Hourly.Time = []string{"2026-07-27T12:00", "2026-07-27T13:00"}
+	r.Hourly.Temperature2m = []float64{20, 21}
+	r.Hourly.ApparentTemperature = []float64{19, 20}
+	r.Hourly.PrecipitationProbability = []float64{0, 10}
+	r.Hourly.Precipitation = []float64{0, 0.1}
+	r.Hourly.WeatherCode = []int{0, 61}
+	r.Hourly.Humidity = []float64{50, 55}
+	r.Hourly.Pressure = []float64{1013, 1012}
+	r.Hourly.WindSpeed = []float64{5, 6}
+	r.Hourly.WindBearing = []float64{180, 190}
+	r.Hourly.IsDay = []int{1, 1}
+
+	r.Daily.Time = []string{"2026-07-27"}
+	r.Daily.WeatherCode = []int{3}
+	r.Daily.TemperatureMax = []float64{22}
+	r.Daily.TemperatureMin = []float64{14}
+	r.Daily.PrecipitationProbability = []float64{20}
+	r.Daily.PrecipitationSum = []float64{1.5}
+	r.Daily.Sunrise = []string{"2026-07-27T05:00"}
+	r.Daily.Sunset = []string{"2026-07-27T21:00"}
+
+	p := &OpenMeteoProvider{}
+	f, err := p.toForecast(r)
+	if err != nil {
+		t.Fatalf("toForecast() error = %v", err)
+	}
+
+	if f.Currently.Icon != "clear-day" || f.Currently.Temperature != 20 {
+		t.Fatalf("Currently = %+v, want clear-day/20", f.Currently)
+	}
+	if len(f.Hourly.Data) != 2 {
+		t.Fatalf("len(Hourly.Data) = %d, want 2", len(f.Hourly.Data))
+	}
+	if f.Hourly.Data[1].Icon != "rain" {
+		t.Fatalf("Hourly.Data[1].Icon = %q, want rain", f.Hourly.Data[1].Icon)
+	}
+	if len(f.Daily.Data) != 1 || f.Daily.Data[0].TemperatureMax != 22 {
+		t.Fatalf("Daily.Data = %+v, want one entry with TemperatureMax 22", f.Daily.Data)
+	}
+	if f.Daily.Data[0].PrecipitationProbability != 0.2 {
+		t.Fatalf("Daily.Data[0].PrecipitationProbability = %v, want 0.2", f.Daily.Data[0].PrecipitationProbability)
+	}
+}
+
+func TestOpenMeteoToForecastRejectsMismatchedArrayLengths(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(r *openMeteoResponse)
+		wantErr string
+	}{
+		{
+			name: "hourly arrays mismatched",
+			mutate: func(r *openMeteoResponse) {
+				r.Hourly.Time = []string{"2026-07-27T12:00", "2026-07-27T13:00"}
+				r.Hourly.Temperature2m = []float64{20} // short by one
+			},
+			wantErr: "hourly",
+		},
+		{
+			name: "daily arrays mismatched",
+			mutate: func(r *openMeteoResponse) {
+				r.Daily.Time = []string{"2026-07-27", "2026-07-28"}
+				r.Daily.TemperatureMax = []float64{22} // short by one
+			},
+			wantErr: "daily",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &openMeteoResponse{}
+			tt.mutate(r)
+
+			p := &OpenMeteoProvider{}
+			_, err := p.toForecast(r)
+			if err == nil {
+				t.Fatal("toForecast() error = nil, want mismatched-length error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("toForecast() error = %q, want it to mention %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+// nwsPeriodJSON builds a single NWS forecast period as JSON, matching the
+// shape api.weather.gov actually returns.
+func nwsPeriodJSON(start time.Time, isDaytime bool, temp float64, short string) string {
+	return `{"startTime":"` + start.Format(time.RFC3339) + `","isDaytime":` +
+		map[bool]string{true: "true", false: "false"}[isDaytime] +
+		`,"temperature":` + jsonNum(temp) + `,"windSpeed":"10 mph","windDirection":"NW",` +
+		`"shortForecast":"` + short + `","probabilityOfPrecipitation":{"value":10}}`
+}
+
+func jsonNum(f float64) string {
+	b, _ := json.Marshal(f)
+	return string(b)
+}
+
+func nwsResponseFromPeriods(periods ...string) *nwsForecastResponse {
+	body := `{"properties":{"periods":[` + strings.Join(periods, ",") + `]}}`
+	var r nwsForecastResponse
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		panic(err)
+	}
+	return &r
+}
+
+func TestNWSToForecast(t *testing.T) {
+	day1 := time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC)
+
+	hourly := nwsResponseFromPeriods(
+		nwsPeriodJSON(day1.Add(12*time.Hour), true, 75, "Sunny"),
+		nwsPeriodJSON(day1.Add(13*time.Hour), true, 76, "Partly Cloudy"),
+	)
+
+	daily := nwsResponseFromPeriods(
+		nwsPeriodJSON(day1, true, 80, "Sunny"),
+		nwsPeriodJSON(day1.Add(12*time.Hour), false, 60, "Clear"),
+	)
+
+	p := &NWSProvider{}
+	f := p.toForecast(40.7, -74.0, hourly, daily, excludeSet(nil))
+
+	if f.Currently.Icon != "clear-day" || f.Currently.Temperature != 75 {
+		t.Fatalf("Currently = %+v, want clear-day/75 from the first hourly period", f.Currently)
+	}
+	if len(f.Hourly.Data) != 2 {
+		t.Fatalf("len(Hourly.Data) = %d, want 2", len(f.Hourly.Data))
+	}
+	if len(f.Daily.Data) != 1 {
+		t.Fatalf("len(Daily.Data) = %d, want 1 (day+night pair collapsed)", len(f.Daily.Data))
+	}
+	d := f.Daily.Data[0]
+	if d.TemperatureMax != 80 || d.TemperatureMin != 60 {
+		t.Fatalf("Daily.Data[0] = %+v, want TemperatureMax 80 / TemperatureMin 60", d)
+	}
+}
+
+func TestNWSToForecastHonorsExcludes(t *testing.T) {
+	day1 := time.Date(2026, 7, 27, 0, 0, 0, 0, time.UTC)
+	hourly := nwsResponseFromPeriods(nwsPeriodJSON(day1, true, 75, "Sunny"))
+
+	p := &NWSProvider{}
+
+	f := p.toForecast(0, 0, hourly, nil, excludeSet([]string{"hourly"}))
+	if len(f.Hourly.Data) != 0 {
+		t.Fatalf("len(Hourly.Data) = %d, want 0 when hourly is excluded", len(f.Hourly.Data))
+	}
+	if f.Currently.Temperature != 75 {
+		t.Fatalf("Currently.Temperature = %v, want 75 (currently not excluded)", f.Currently.Temperature)
+	}
+
+	f = p.toForecast(0, 0, hourly, nil, excludeSet([]string{"currently"}))
+	if f.Currently.Temperature != 0 {
+		t.Fatalf("Currently.Temperature = %v, want 0 when currently is excluded", f.Currently.Temperature)
+	}
+	if len(f.Hourly.Data) != 1 {
+		t.Fatalf("len(Hourly.Data) = %d, want 1 (hourly not excluded)", len(f.Hourly.Data))
+	}
+
+	if f := p.toForecast(0, 0, nil, nil, excludeSet(nil)); len(f.Hourly.Data) != 0 || len(f.Daily.Data) != 0 {
+		t.Fatalf("toForecast with nil hourly/daily = %+v, want an empty Forecast", f)
+	}
+}
+
+func TestBBCToForecast(t *testing.T) {
+	resp := &bbcResponse{}
+	resp.Forecasts = append(resp.Forecasts, struct {
+		Summary struct {
+			Report bbcReport `json:"report"`
+		} `json:"summary"`
+		Detailed struct {
+			ReportList []bbcReport `json:"reportList"`
+		} `json:"detailed"`
+	}{})
+	resp.Forecasts[0].Summary.Report = bbcReport{
+		LocalDate: "2026-07-27", Timeslot: "12:00",
+		WeatherType: 4, WeatherTypeText: "Sunny", TemperatureC: 22,
+	}
+	resp.Forecasts[0].Detailed.ReportList = []bbcReport{
+		{LocalDate: "2026-07-27", Timeslot: "12:00", WeatherType: 4, WeatherTypeText: "Sunny", TemperatureC: 22, PrecipitationProbabilityInPercent: "5"},
+		{LocalDate: "2026-07-27", Timeslot: "13:00", WeatherType: 7, WeatherTypeText: "Partly Cloudy", TemperatureC: 23, PrecipitationProbabilityInPercent: "10"},
+	}
+
+	p := &BBCProvider{}
+
+	f := p.toForecast(resp, excludeSet(nil))
+	if f.Currently.Icon != "clear-day" || f.Currently.Temperature != 22 {
+		t.Fatalf("Currently = %+v, want clear-day/22", f.Currently)
+	}
+	if len(f.Hourly.Data) != 2 {
+		t.Fatalf("len(Hourly.Data) = %d, want 2", len(f.Hourly.Data))
+	}
+	if len(f.Daily.Data) != 1 {
+		t.Fatalf("len(Daily.Data) = %d, want 1", len(f.Daily.Data))
+	}
+
+	excluded := p.toForecast(resp, excludeSet([]string{"hourly", "daily", "currently"}))
+	if excluded.Currently.Icon != "" {
+		t.Fatalf("Currently = %+v, want zero value when currently is excluded", excluded.Currently)
+	}
+	if len(excluded.Hourly.Data) != 0 || len(excluded.Daily.Data) != 0 {
+		t.Fatalf("Hourly/Daily = %+v / %+v, want both empty when excluded", excluded.Hourly, excluded.Daily)
+	}
+}
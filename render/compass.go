@@ -0,0 +1,24 @@
+package render
+
+import "math"
+
+// compassArrows holds, for each 16-point compass direction the wind is
+// blowing FROM (N, NNE, NE, ENE, ... NNW, the order windArrow indexes
+// into), the arrow glyph pointing the opposite way: the direction the wind
+// is blowing TO.
+var compassArrows = [16]string{
+	"↓", "↙", "↙", "↙", // N, NNE, NE, ENE
+	"←", "↖", "↖", "↖", // E, ESE, SE, SSE
+	"↑", "↗", "↗", "↗", // S, SSW, SW, WSW
+	"→", "↘", "↘", "↘", // W, WNW, NW, NNW
+}
+
+// windArrow picks one of the 16-point compass arrows for bearing, which is
+// the meteorological convention Forecast.Currently.WindBearing uses:
+// degrees clockwise from north, the direction the wind is blowing from.
+// The returned arrow points the opposite way, in the direction the wind is
+// blowing towards.
+func windArrow(bearing float64) string {
+	idx := int(math.Mod(math.Mod(bearing, 360)+360+11.25, 360) / 22.5)
+	return compassArrows[idx]
+}
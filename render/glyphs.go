@@ -0,0 +1,99 @@
+package render
+
+// glyph is a small, fixed-height piece of ASCII art representing one of
+// the Dark Sky-style icon strings forecastio.Forecast populates on
+// Currently/Hourly/Daily, in the style of wttr.in's terminal weather
+// panels.
+type glyph [5]string
+
+// glyphs maps a Forecast icon string onto its ASCII art. Unrecognised or
+// empty icons fall back to glyphs["cloudy"], the most neutral entry, so
+// Terminal always has something to draw.
+var glyphs = map[string]glyph{
+	"clear-day": {
+		`    \   /    `,
+		`     .-.     `,
+		`  ‒ (   ) ‒  `,
+		"     \\`-'     ",
+		`    /   \    `,
+	},
+	"clear-night": {
+		`            `,
+		`    .-.     `,
+		`   (   ).   `,
+		`  (___(__)  `,
+		`            `,
+	},
+	"partly-cloudy-day": {
+		`   \  /      `,
+		` _ /"".-.    `,
+		`   \_(   ).  `,
+		`   /(___(__) `,
+		`             `,
+	},
+	"partly-cloudy-night": {
+		`             `,
+		`   .-.       `,
+		`  (   ).--.  `,
+		` (___(____)  `,
+		`             `,
+	},
+	"cloudy": {
+		`             `,
+		`     .--.    `,
+		`  .-(    ).  `,
+		` (___.__)__) `,
+		`             `,
+	},
+	"rain": {
+		`     .-.     `,
+		`    (   ).   `,
+		`   (___(__)  `,
+		`    ' ' ' '  `,
+		`   ' ' ' '   `,
+	},
+	"sleet": {
+		`     .-.     `,
+		`    (   ).   `,
+		`   (___(__)  `,
+		`    ' * ' *  `,
+		`   * ' * '   `,
+	},
+	"snow": {
+		`     .-.     `,
+		`    (   ).   `,
+		`   (___(__)  `,
+		`    *  *  *  `,
+		`   *  *  *   `,
+	},
+	"wind": {
+		`             `,
+		`   ~~\       `,
+		`  ~~~~\--    `,
+		`   ~~~/      `,
+		`             `,
+	},
+	"fog": {
+		`             `,
+		`  _ - _ - _  `,
+		` _ - _ - _ - `,
+		`  _ - _ - _  `,
+		`             `,
+	},
+	"thunderstorm": {
+		`     .-.     `,
+		`    (   ).   `,
+		`   (___(__)  `,
+		`    ⚡' ⚡'   `,
+		`   ' ⚡ '     `,
+	},
+}
+
+// glyphFor returns the ASCII art for icon, falling back to the cloudy
+// glyph for anything this package doesn't recognise.
+func glyphFor(icon string) glyph {
+	if g, ok := glyphs[icon]; ok {
+		return g
+	}
+	return glyphs["cloudy"]
+}
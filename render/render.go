@@ -0,0 +1,111 @@
+// Package render turns a *forecastio.Forecast into a wttr.in-style
+// terminal panel: a current-conditions block, a 24-hour strip and a
+// multi-day table, so CLI users get something usable without hand-rolling
+// fmt.Printf loops.
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alsm/forecastio"
+)
+
+// Options controls how Terminal renders a Forecast.
+type Options struct {
+	// Days is how many entries of Forecast.Daily.Data to render; 0 means
+	// all of them.
+	Days int
+	// Color enables ANSI 256-color output. When false, Terminal produces
+	// plain text.
+	Color bool
+	// Units is used only to label temperatures (°C or °F); it does not
+	// convert any values, which are rendered exactly as they appear on
+	// the Forecast.
+	Units string
+	// Now highlights the current hour's column in the 24-hour strip. The
+	// zero Time disables highlighting.
+	Now time.Time
+}
+
+// degreeLabel returns "°F" for imperial-ish unit settings and "°C"
+// otherwise, matching forecast.io's units parameter.
+func (o Options) degreeLabel() string {
+	switch o.Units {
+	case "us":
+		return "°F"
+	default:
+		return "°C"
+	}
+}
+
+// Terminal renders f as a multi-line terminal panel.
+func Terminal(f *forecastio.Forecast, opts Options) string {
+	var b strings.Builder
+
+	renderCurrent(&b, f, opts)
+	b.WriteString("\n")
+	renderHourly(&b, f, opts)
+	b.WriteString("\n")
+	renderDaily(&b, f, opts)
+
+	return b.String()
+}
+
+func renderCurrent(b *strings.Builder, f *forecastio.Forecast, opts Options) {
+	g := glyphFor(f.Currently.Icon)
+	lines := [5]string{
+		fmt.Sprintf("%.0f%s", f.Currently.Temperature, opts.degreeLabel()),
+		f.Currently.Summary,
+		fmt.Sprintf("Wind %s %.0f", windArrow(f.Currently.WindBearing), f.Currently.WindSpeed),
+		fmt.Sprintf("Precip %.0f%%", f.Currently.PrecipitationProbability*100),
+		fmt.Sprintf("Visibility %.0f", f.Currently.Visibility),
+	}
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(b, "%-14s %s\n", g[i], colorize(opts, lines[i]))
+	}
+}
+
+func renderHourly(b *strings.Builder, f *forecastio.Forecast, opts Options) {
+	if len(f.Hourly.Data) == 0 {
+		return
+	}
+	b.WriteString("Next 24 hours:\n")
+	hours := f.Hourly.Data
+	if len(hours) > 24 {
+		hours = hours[:24]
+	}
+	for _, h := range hours {
+		marker := "  "
+		if !opts.Now.IsZero() && h.Time.Hour() == opts.Now.Hour() && h.Time.Day() == opts.Now.Day() {
+			marker = "> "
+		}
+		fmt.Fprintf(b, "%s%s  %3.0f%s  %s  %s\n",
+			marker, h.Time.Format("15:04"), h.Temperature, opts.degreeLabel(), windArrow(h.WindBearing), h.Summary)
+	}
+}
+
+func renderDaily(b *strings.Builder, f *forecastio.Forecast, opts Options) {
+	days := f.Daily.Data
+	if opts.Days > 0 && opts.Days < len(days) {
+		days = days[:opts.Days]
+	}
+	if len(days) == 0 {
+		return
+	}
+	b.WriteString("Forecast:\n")
+	for _, d := range days {
+		fmt.Fprintf(b, "%s  %3.0f/%3.0f%s  %s\n",
+			d.Time.Format("Mon 02 Jan"), d.TemperatureMin, d.TemperatureMax, opts.degreeLabel(), d.Summary)
+	}
+}
+
+// colorize wraps s in an ANSI 256-color escape sequence when opts.Color is
+// set, leaving it unchanged otherwise.
+func colorize(opts Options, s string) string {
+	if !opts.Color {
+		return s
+	}
+	return "\x1b[38;5;117m" + s + "\x1b[0m"
+}
@@ -0,0 +1,94 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alsm/forecastio"
+)
+
+func TestWindArrow(t *testing.T) {
+	tests := []struct {
+		bearing float64
+		want    string
+	}{
+		{bearing: 0, want: "↓"},   // wind from the north blows south
+		{bearing: 90, want: "←"},  // wind from the east blows west
+		{bearing: 180, want: "↑"}, // wind from the south blows north
+		{bearing: 270, want: "→"}, // wind from the west blows east
+		{bearing: 360, want: "↓"}, // wraps back to north
+		{bearing: -10, want: "↓"}, // negative bearings still resolve
+	}
+
+	for _, tt := range tests {
+		if got := windArrow(tt.bearing); got != tt.want {
+			t.Errorf("windArrow(%v) = %q, want %q", tt.bearing, got, tt.want)
+		}
+	}
+}
+
+func TestGlyphForFallsBackToCloudy(t *testing.T) {
+	if glyphFor("not-a-real-icon") != glyphs["cloudy"] {
+		t.Fatal("glyphFor of an unrecognised icon did not fall back to cloudy")
+	}
+	if glyphFor("") != glyphs["cloudy"] {
+		t.Fatal("glyphFor of an empty icon did not fall back to cloudy")
+	}
+	if glyphFor("clear-day") != glyphs["clear-day"] {
+		t.Fatal("glyphFor of a known icon did not return its own glyph")
+	}
+}
+
+func TestTerminalRendersCurrentConditions(t *testing.T) {
+	f := &forecastio.Forecast{}
+	f.Currently.Icon = "clear-day"
+	f.Currently.Summary = "Clear"
+	f.Currently.Temperature = 21
+	f.Currently.WindBearing = 180
+	f.Currently.WindSpeed = 5
+	f.Currently.PrecipitationProbability = 0.1
+	f.Currently.Visibility = 10
+
+	out := Terminal(f, Options{Units: "us"})
+
+	for _, want := range []string{"21°F", "Clear", "Wind ↑ 5"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Terminal output missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestTerminalOmitsEmptyHourlyAndDailySections(t *testing.T) {
+	f := &forecastio.Forecast{}
+	out := Terminal(f, Options{})
+
+	if strings.Contains(out, "Next 24 hours:") {
+		t.Error("Terminal rendered an hourly section with no hourly data")
+	}
+	if strings.Contains(out, "Forecast:") {
+		t.Error("Terminal rendered a daily section with no daily data")
+	}
+}
+
+func TestTerminalRespectsDaysLimit(t *testing.T) {
+	var f forecastio.Forecast
+	body := `{"daily":{"data":[
+		{"time":1,"summary":"Clear day 1"},
+		{"time":2,"summary":"Clear day 2"},
+		{"time":3,"summary":"Clear day 3"},
+		{"time":4,"summary":"Clear day 4"},
+		{"time":5,"summary":"Clear day 5"}
+	]}}`
+	if err := json.Unmarshal([]byte(body), &f); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	out := Terminal(&f, Options{Days: 2})
+	if n := strings.Count(out, "Clear day"); n != 2 {
+		t.Errorf("Terminal with Days: 2 rendered %d daily rows, want 2:\n%s", n, out)
+	}
+	if !strings.Contains(out, "Clear day 1") || !strings.Contains(out, "Clear day 2") {
+		t.Errorf("Terminal with Days: 2 should render the first two days, got:\n%s", out)
+	}
+}
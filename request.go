@@ -0,0 +1,119 @@
+package forecastio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+// langSet is the set of language codes forecast.io accepts for
+// Currently.Summary and per-hour/day summary text.
+var langSet = map[string]struct{}{
+	"ar": {}, "de": {}, "en": {}, "es": {}, "fr": {}, "it": {}, "ja": {},
+	"nl": {}, "pl": {}, "pt": {}, "ru": {}, "sv": {}, "tr": {}, "uk": {},
+	"x-pig-latin": {}, "zh": {}, "zh-tw": {},
+}
+
+// ForecastRequest describes a single forecast.io request. It replaces the
+// growing list of positional parameters on Forecast/ForecastAtTime with a
+// single extensible value; fields left at their zero value fall back to
+// the APIConn's own Units() and to forecast.io's defaults.
+type ForecastRequest struct {
+	Latitude     float64
+	Longitude    float64
+	Time         *time.Time
+	Excludes     []string
+	ExtendHourly bool
+	Units        string
+	Lang         string
+}
+
+// forecastQuery is the querystring shape a ForecastRequest is encoded to,
+// via github.com/google/go-querystring, rather than building the query
+// string with fmt.Sprintf.
+type forecastQuery struct {
+	Units    string   `url:"units"`
+	Excludes []string `url:"exclude,comma"`
+	Lang     string   `url:"lang,omitempty"`
+	Extend   string   `url:"extend,omitempty"`
+}
+
+// Do requests a forecast from forecastio using the APIConn a for the given
+// ForecastRequest, aborting the request if ctx is cancelled or times out.
+// It is the most general entry point into this package; Forecast,
+// ForecastContext, ForecastAtTime and ForecastAtTimeContext are all thin
+// wrappers around it.
+func (a *APIConn) Do(ctx context.Context, r ForecastRequest) (*Forecast, error) {
+	for _, ex := range r.Excludes {
+		if ex == "" {
+			continue
+		}
+		if _, ok := excludesSet[ex]; !ok {
+			return nil, errors.New("Invalid exclude requested")
+		}
+	}
+
+	units := r.Units
+	if units == "" {
+		units = a.Units()
+	}
+	if _, ok := unitsSet[units]; !ok {
+		return nil, errors.New("Invalid units requested")
+	}
+
+	if r.Lang != "" {
+		if _, ok := langSet[r.Lang]; !ok {
+			return nil, errors.New("Invalid lang requested")
+		}
+	}
+
+	extend := ""
+	if r.ExtendHourly {
+		extend = "hourly"
+	}
+
+	values, err := query.Values(forecastQuery{
+		Units:    units,
+		Excludes: r.Excludes,
+		Lang:     r.Lang,
+		Extend:   extend,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%s/%s/%f,%f", a.baseURL, a.apiKey, r.Latitude, r.Longitude)
+	if r.Time != nil {
+		path = fmt.Sprintf("%s,%d", path, r.Time.Unix())
+	}
+
+	return a.fetch(ctx, path+"?"+values.Encode())
+}
+
+// timeFromDate converts the interface{} accepted by ForecastAtTime/
+// ForecastAtTimeContext into a time.Time, supporting the historical
+// time.Time/int/int64/string forms described on ForecastAtTimeContext.
+func timeFromDate(date interface{}) (time.Time, error) {
+	switch d := date.(type) {
+	case time.Time:
+		return d, nil
+	case int:
+		return time.Unix(int64(d), 0), nil
+	case int64:
+		return time.Unix(d, 0), nil
+	case string:
+		if unix, err := strconv.ParseInt(d, 10, 64); err == nil {
+			return time.Unix(unix, 0), nil
+		}
+		if t, err := time.Parse("2006-01-02T15:04:05Z0700", d); err == nil {
+			return t, nil
+		}
+		return time.Parse("2006-01-02T15:04:05", d)
+	default:
+		return time.Time{}, fmt.Errorf("forecastio: unsupported date type %T", date)
+	}
+}
@@ -0,0 +1,91 @@
+package forecastio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ForecastRequest
+		wantErr string
+	}{
+		{
+			name:    "invalid units",
+			req:     ForecastRequest{Units: "kelvin"},
+			wantErr: "Invalid units requested",
+		},
+		{
+			name:    "invalid exclude",
+			req:     ForecastRequest{Excludes: []string{"weekly"}},
+			wantErr: "Invalid exclude requested",
+		},
+		{
+			name:    "invalid lang",
+			req:     ForecastRequest{Lang: "klingon"},
+			wantErr: "Invalid lang requested",
+		},
+		{
+			name: "blank excludes entries are ignored",
+			req:  ForecastRequest{Excludes: []string{""}},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	a := NewConnection("testkey", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := a.Do(context.Background(), tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Do() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Do() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDoBuildsExpectedQuery(t *testing.T) {
+	var gotPath, gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	a := NewConnection("testkey", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+
+	_, err := a.Do(context.Background(), ForecastRequest{
+		Latitude: 51.5, Longitude: -0.12,
+		Excludes: []string{"minutely", "alerts"}, Lang: "fr", ExtendHourly: true,
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if !strings.Contains(gotPath, "/testkey/51.500000,-0.120000") {
+		t.Fatalf("request path = %q, want it to contain the api key and coordinates", gotPath)
+	}
+	if !strings.Contains(gotQuery, "exclude=minutely%2Calerts") {
+		t.Fatalf("request query = %q, want it to contain exclude=minutely%%2Calerts", gotQuery)
+	}
+	if !strings.Contains(gotQuery, "lang=fr") {
+		t.Fatalf("request query = %q, want it to contain lang=fr", gotQuery)
+	}
+	if !strings.Contains(gotQuery, "extend=hourly") {
+		t.Fatalf("request query = %q, want it to contain extend=hourly", gotQuery)
+	}
+}